@@ -0,0 +1,367 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/viswasandrews/fetch-receipt-solution/idempotency"
+	"github.com/viswasandrews/fetch-receipt-solution/metrics"
+	"github.com/viswasandrews/fetch-receipt-solution/middleware"
+	"github.com/viswasandrews/fetch-receipt-solution/query"
+	"github.com/viswasandrews/fetch-receipt-solution/receipt"
+	"github.com/viswasandrews/fetch-receipt-solution/rules"
+	"github.com/viswasandrews/fetch-receipt-solution/validation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// defaultRulesConfigPath is used when RULES_CONFIG_PATH isn't set.
+const defaultRulesConfigPath = "rules.yaml"
+
+type PointsResponse struct {
+	Points int `json:"points"`
+}
+
+var (
+	mongoClient *mongo.Client
+	receiptsCol *mongo.Collection
+	idemStore   *idempotency.Store
+	ruleEngine  *rules.Engine
+	logger      = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+)
+
+// config holds the server's environment-driven settings.
+type config struct {
+	HTTPPort        string
+	MongoURI        string
+	RulesConfigPath string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	ShutdownGrace   time.Duration
+}
+
+// loadConfig reads HTTP_PORT, MONGO_URI, RULES_CONFIG_PATH, READ_TIMEOUT,
+// WRITE_TIMEOUT, and SHUTDOWN_GRACE from the environment, falling back to
+// sensible defaults.
+func loadConfig() config {
+	return config{
+		HTTPPort:        getEnv("HTTP_PORT", "8080"),
+		MongoURI:        getEnv("MONGO_URI", "mongodb://mongo:27017"),
+		RulesConfigPath: getEnv("RULES_CONFIG_PATH", defaultRulesConfigPath),
+		ReadTimeout:     getEnvDuration("READ_TIMEOUT", 10*time.Second),
+		WriteTimeout:    getEnvDuration("WRITE_TIMEOUT", 10*time.Second),
+		ShutdownGrace:   getEnvDuration("SHUTDOWN_GRACE", 15*time.Second),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		logger.Warn("invalid duration env value, using default", "key", key, "value", v, "default", fallback, "error", err)
+		return fallback
+	}
+	return d
+}
+
+func ConnectToMongoDB(uri string) (*mongo.Client, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clientOptions := options.Client().ApplyURI(uri)
+
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			client.Disconnect(ctx)
+		}
+	}()
+
+	err = client.Ping(ctx, readpref.Primary())
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("connected to MongoDB")
+
+	return client, nil
+}
+
+func processReceipt(w http.ResponseWriter, r *http.Request) {
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var rcpt receipt.Receipt
+	if err := json.Unmarshal(body, &rcpt); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if fieldErrs := validation.Validate(&rcpt); len(fieldErrs) > 0 {
+		validation.WriteProblem(w, fieldErrs)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// An Idempotency-Key lets a client safely retry a POST without
+	// inserting a duplicate receipt, the way payment-processing APIs do.
+	// Begin atomically claims the key so two concurrent requests for the
+	// same new key can't both insert a receipt.
+	idemKey := r.Header.Get("Idempotency-Key")
+	if idemKey != "" {
+		claimed, existingID, err := idemStore.Begin(ctx, idemKey, body)
+		if errors.Is(err, idempotency.ErrKeyConflict) || errors.Is(err, idempotency.ErrInProgress) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !claimed {
+			response := map[string]string{"id": existingID}
+			jsonResponse, _ := json.Marshal(response)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write(jsonResponse)
+			return
+		}
+	}
+
+	// Generate a unique ID for the receipt and persist its points so
+	// list/stats aggregations don't have to re-run the rules engine.
+	rcpt.ID = uuid.New().String()
+	explanation := ruleEngine.Explain(&rcpt)
+	rcpt.Points = explanation.Total
+	rcpt.Explanation = explanation
+
+	err = metrics.ObserveMongoOp("insert", func() error {
+		_, err := receiptsCol.InsertOne(ctx, rcpt)
+		return err
+	})
+	if err != nil {
+		if idemKey != "" {
+			if abortErr := idemStore.Abort(ctx, idemKey); abortErr != nil {
+				logger.Error("failed to release idempotency claim", "error", abortErr)
+			}
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	metrics.ReceiptsIngestedTotal.Inc()
+	for _, result := range explanation.Results {
+		metrics.PointsAwardedTotal.WithLabelValues(result.Rule).Add(float64(result.Points))
+	}
+
+	if idemKey != "" {
+		if err := idemStore.Finish(ctx, idemKey, rcpt.ID); err != nil {
+			logger.Error("failed to record idempotency response", "error", err)
+		}
+	}
+
+	// Respond with the receipt ID
+	response := map[string]string{"id": rcpt.ID}
+	jsonResponse, _ := json.Marshal(response)
+
+	logger.Info("receipt ingested", "requestId", middleware.FromContext(r.Context()), "id", rcpt.ID, "points", rcpt.Points)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResponse)
+
+}
+
+func getPoints(w http.ResponseWriter, r *http.Request) {
+
+	// Extract the receipt ID from the URL path
+	id := mux.Vars(r)["id"]
+	var rcpt receipt.Receipt
+
+	// Find the receipt in MongoDB by ID
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := metrics.ObserveMongoOp("findone", func() error {
+		return receiptsCol.FindOne(ctx, bson.M{"_id": id}).Decode(&rcpt)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	var jsonResponse []byte
+
+	// ?explain=1 returns the per-rule breakdown instead of just the total.
+	// This reads the breakdown persisted at insert time, not a live
+	// recomputation, so it always agrees with the stored Points even if
+	// the rules config has since been retuned.
+	if r.URL.Query().Get("explain") == "1" {
+		jsonResponse, _ = json.Marshal(rcpt.Explanation)
+		logger.Info("points explained", "requestId", middleware.FromContext(r.Context()), "id", id, "total", rcpt.Explanation.Total)
+	} else {
+		response := PointsResponse{Points: rcpt.Points}
+		jsonResponse, _ = json.Marshal(response)
+		logger.Info("points read", "requestId", middleware.FromContext(r.Context()), "id", id, "points", rcpt.Points)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResponse)
+
+}
+
+func listReceipts(w http.ResponseWriter, r *http.Request) {
+
+	filter, err := query.ParseFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	page, err := query.List(ctx, receiptsCol, filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse, _ := json.Marshal(page)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResponse)
+
+}
+
+func receiptStats(w http.ResponseWriter, r *http.Request) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stats, err := query.Aggregate(ctx, receiptsCol)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse, _ := json.Marshal(stats)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResponse)
+
+}
+
+func main() {
+	cfg := loadConfig()
+
+	var err error
+	mongoClient, err = ConnectToMongoDB(cfg.MongoURI)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	receiptsCol = mongoClient.Database("receipt-processor").Collection("receipts")
+	idemStore = idempotency.NewStore(mongoClient.Database("receipt-processor").Collection("idempotency"))
+
+	indexCtx, indexCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := idemStore.EnsureIndexes(indexCtx); err != nil {
+		log.Fatal(err)
+	}
+	if err := query.EnsureIndexes(indexCtx, receiptsCol); err != nil {
+		log.Fatal(err)
+	}
+	indexCancel()
+
+	ruleEngine, err = rules.LoadEngine(cfg.RulesConfigPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Create a new router
+	router := mux.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(middleware.Logging(logger))
+	router.HandleFunc("/api/receipts", processReceipt).Methods("POST")
+	router.HandleFunc("/api/receipts", listReceipts).Methods("GET")
+	router.HandleFunc("/api/receipts/stats", receiptStats).Methods("GET")
+	router.HandleFunc("/api/receipts/{id}/points", getPoints).Methods("GET")
+	// Deprecated alias for the pre-rules-engine read path; kept so existing
+	// clients of GET /api/receipts/{id} don't break. Registered after the
+	// literal /api/receipts/stats route so "stats" can't be captured as {id}.
+	router.HandleFunc("/api/receipts/{id}", getPoints).Methods("GET")
+	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+
+	// router.Use only wraps matched routes, so unmatched paths and method
+	// mismatches need their own middleware chain to still get a request ID
+	// and show up in logs/metrics.
+	router.NotFoundHandler = middleware.RequestID(middleware.Logging(logger)(http.HandlerFunc(http.NotFound)))
+	router.MethodNotAllowedHandler = middleware.RequestID(middleware.Logging(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})))
+
+	srv := &http.Server{
+		Addr:         ":" + cfg.HTTPPort,
+		Handler:      router,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  2 * cfg.ReadTimeout,
+	}
+
+	go func() {
+		logger.Info("server starting", "port", cfg.HTTPPort)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.ShutdownGrace)
+	defer shutdownCancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("server shutdown error", "error", err)
+	}
+	if err := mongoClient.Disconnect(shutdownCtx); err != nil {
+		logger.Error("mongo disconnect error", "error", err)
+	}
+}
@@ -0,0 +1,117 @@
+// Package idempotency resolves duplicate POST /api/receipts submissions
+// using a client-supplied Idempotency-Key, the way payment-processing
+// services dedupe retried writes.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TTL is how long an idempotency record is retained before Mongo expires it.
+const TTL = 24 * time.Hour
+
+// ErrKeyConflict is returned when an Idempotency-Key is reused with a
+// request body that doesn't match the one it was first used with.
+var ErrKeyConflict = errors.New("idempotency key reused with a different payload")
+
+// ErrInProgress is returned when another request already claimed this key
+// and hasn't recorded a response yet.
+var ErrInProgress = errors.New("idempotency key is still being processed by another request")
+
+// record is the document stored per idempotency key.
+type record struct {
+	Key        string    `bson:"_id"`
+	BodyHash   string    `bson:"bodyHash"`
+	ResponseID string    `bson:"responseId"`
+	CreatedAt  time.Time `bson:"createdAt"`
+}
+
+// Store resolves idempotent requests against a Mongo collection.
+type Store struct {
+	col *mongo.Collection
+}
+
+// NewStore wraps the given collection as an idempotency Store.
+func NewStore(col *mongo.Collection) *Store {
+	return &Store{col: col}
+}
+
+// EnsureIndexes creates the TTL index that expires idempotency records
+// after TTL. It is safe to call on every startup.
+func (s *Store) EnsureIndexes(ctx context.Context) error {
+	_, err := s.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "createdAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(TTL.Seconds())),
+	})
+	return err
+}
+
+// hash binds the idempotency key to the request body so a replayed key
+// used with a different payload can be detected instead of silently
+// returning the wrong response.
+func hash(key string, body []byte) string {
+	h := sha256.Sum256(append([]byte(key+"|"), body...))
+	return hex.EncodeToString(h[:])
+}
+
+// Begin atomically claims key for body via an upsert, so two concurrent
+// requests for the same new key can never both proceed to insert a
+// receipt. If claimed is true, the caller won the race and must do the
+// work and call Finish (or Abort on failure). If claimed is false,
+// responseID holds the already-completed response for this key; it is
+// empty with err set to ErrInProgress if another request is still
+// working on it, or ErrKeyConflict if the body doesn't match.
+func (s *Store) Begin(ctx context.Context, key string, body []byte) (claimed bool, responseID string, err error) {
+	h := hash(key, body)
+
+	result, err := s.col.UpdateOne(ctx, bson.M{"_id": key}, bson.M{
+		"$setOnInsert": bson.M{
+			"bodyHash":  h,
+			"createdAt": time.Now(),
+		},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, "", err
+	}
+	if result.UpsertedCount == 1 {
+		return true, "", nil
+	}
+
+	var rec record
+	if err := s.col.FindOne(ctx, bson.M{"_id": key}).Decode(&rec); err != nil {
+		return false, "", err
+	}
+	if rec.BodyHash != h {
+		return false, "", ErrKeyConflict
+	}
+	if rec.ResponseID == "" {
+		return false, "", ErrInProgress
+	}
+	return false, rec.ResponseID, nil
+}
+
+// Finish records the response ID for a key claimed via Begin, so
+// subsequent retries within TTL can be replayed instead of inserting a
+// duplicate receipt.
+func (s *Store) Finish(ctx context.Context, key, responseID string) error {
+	_, err := s.col.UpdateOne(ctx, bson.M{"_id": key}, bson.M{
+		"$set": bson.M{"responseId": responseID},
+	})
+	return err
+}
+
+// Abort releases a claim made via Begin without recording a response, so
+// a later retry with the same key can claim it again. Call this when the
+// work that followed Begin failed.
+func (s *Store) Abort(ctx context.Context, key string) error {
+	_, err := s.col.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
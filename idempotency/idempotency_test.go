@@ -0,0 +1,20 @@
+package idempotency
+
+import "testing"
+
+// Begin/Finish/Abort talk to a real Mongo collection and aren't covered
+// here; hash is the pure logic that decides whether a replayed key is
+// treated as a retry of the same request or a conflicting reuse.
+func TestHash(t *testing.T) {
+	if got := hash("key-1", []byte(`{"retailer":"Target"}`)); got != hash("key-1", []byte(`{"retailer":"Target"}`)) {
+		t.Errorf("hash() is not deterministic: got %q twice with different results", got)
+	}
+
+	if hash("key-1", []byte("body")) == hash("key-1", []byte("body-2")) {
+		t.Error("hash() collided for the same key with a different body")
+	}
+
+	if hash("key-1", []byte("body")) == hash("key-2", []byte("body")) {
+		t.Error("hash() collided for a different key with the same body")
+	}
+}
@@ -0,0 +1,45 @@
+// Package metrics defines the Prometheus collectors exposed on /metrics.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// ReceiptsIngestedTotal counts successfully ingested receipts.
+	ReceiptsIngestedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "receipts_ingested_total",
+		Help: "Total number of receipts successfully ingested.",
+	})
+
+	// PointsAwardedTotal counts points awarded, labeled by the rule that
+	// awarded them.
+	PointsAwardedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "points_awarded_total",
+		Help: "Total points awarded, labeled by the awarding rule.",
+	}, []string{"rule"})
+
+	// MongoOpDuration measures how long Mongo operations take, labeled by op.
+	MongoOpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "mongo_op_duration_seconds",
+		Help: "Duration of Mongo operations in seconds.",
+	}, []string{"op"})
+
+	// HTTPRequestDuration measures how long HTTP requests take, labeled by
+	// route and status code.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "http_request_duration_seconds",
+		Help: "Duration of HTTP requests in seconds.",
+	}, []string{"route", "code"})
+)
+
+// ObserveMongoOp runs fn, recording its duration under MongoOpDuration{op}.
+func ObserveMongoOp(op string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	MongoOpDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+	return err
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/viswasandrews/fetch-receipt-solution/metrics"
+)
+
+// statusRecorder captures the status code written by the wrapped handler,
+// since http.ResponseWriter doesn't expose it after the fact.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Logging logs each request (request ID, route, status, latency) with
+// logger and records it under http_request_duration_seconds.
+func Logging(logger *slog.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			route := routeTemplate(r)
+			duration := time.Since(start)
+
+			logger.Info("http_request",
+				"requestId", FromContext(r.Context()),
+				"route", route,
+				"method", r.Method,
+				"status", rec.status,
+				"durationMs", duration.Milliseconds(),
+			)
+
+			metrics.HTTPRequestDuration.
+				WithLabelValues(route, strconv.Itoa(rec.status)).
+				Observe(duration.Seconds())
+		})
+	}
+}
+
+// routeTemplate returns the matched mux route's path template (e.g.
+// "/api/receipts/{id}/points") so metrics don't get a label per distinct ID.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return r.URL.Path
+}
@@ -0,0 +1,38 @@
+// Package middleware holds the router-level HTTP middleware: request ID
+// propagation and request logging/metrics.
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "requestID"
+
+// RequestIDHeader is the header clients can set (or read back) to
+// correlate requests across logs.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID attaches a request ID to the request context and echoes it
+// back on the response, generating one if the client didn't supply it.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = uuid.New().String()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// FromContext returns the request ID stored in ctx, or "" if none was set.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
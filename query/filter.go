@@ -0,0 +1,71 @@
+// Package query implements the filtered listing and stats aggregation
+// endpoints for receipts, using Mongo's aggregation framework so results
+// don't require re-scoring receipts on every request.
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// defaultLimit is the page size used when the caller doesn't supply one.
+const defaultLimit = 20
+
+// Filter is a typed set of filters for GET /api/receipts.
+type Filter struct {
+	Retailer  string
+	From      *time.Time
+	To        *time.Time
+	MinPoints *int
+	// Cursor is the last receipt _id seen by the caller; results start
+	// strictly after it. Empty means start from the beginning.
+	Cursor string
+	Limit  int64
+}
+
+// ParseFilter builds a Filter from GET /api/receipts query parameters.
+// "page" carries the opaque cursor (the last _id seen), not a page number,
+// so that pagination stays stable as new receipts are inserted.
+func ParseFilter(values url.Values) (Filter, error) {
+	f := Filter{
+		Retailer: values.Get("retailer"),
+		Cursor:   values.Get("page"),
+		Limit:    defaultLimit,
+	}
+
+	if from := values.Get("from"); from != "" {
+		t, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return Filter{}, fmt.Errorf("invalid from date %q: %w", from, err)
+		}
+		f.From = &t
+	}
+
+	if to := values.Get("to"); to != "" {
+		t, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return Filter{}, fmt.Errorf("invalid to date %q: %w", to, err)
+		}
+		f.To = &t
+	}
+
+	if minPoints := values.Get("minPoints"); minPoints != "" {
+		n, err := strconv.Atoi(minPoints)
+		if err != nil {
+			return Filter{}, fmt.Errorf("invalid minPoints %q: %w", minPoints, err)
+		}
+		f.MinPoints = &n
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil || n <= 0 {
+			return Filter{}, fmt.Errorf("invalid limit %q", limit)
+		}
+		f.Limit = n
+	}
+
+	return f, nil
+}
@@ -0,0 +1,79 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseFilterDefaults(t *testing.T) {
+	f, err := ParseFilter(url.Values{})
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %v", err)
+	}
+	if f.Limit != defaultLimit {
+		t.Errorf("Limit = %d, want default %d", f.Limit, defaultLimit)
+	}
+	if f.From != nil || f.To != nil || f.MinPoints != nil || f.Cursor != "" {
+		t.Errorf("ParseFilter() = %+v, want all optional fields unset", f)
+	}
+}
+
+func TestParseFilterFields(t *testing.T) {
+	values := url.Values{
+		"retailer":  {"Target"},
+		"page":      {"some-cursor-id"},
+		"from":      {"2022-01-01"},
+		"to":        {"2022-12-31"},
+		"minPoints": {"50"},
+		"limit":     {"10"},
+	}
+
+	f, err := ParseFilter(values)
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %v", err)
+	}
+
+	if f.Retailer != "Target" {
+		t.Errorf("Retailer = %q, want %q", f.Retailer, "Target")
+	}
+	if f.Cursor != "some-cursor-id" {
+		t.Errorf("Cursor = %q, want %q", f.Cursor, "some-cursor-id")
+	}
+	if f.Limit != 10 {
+		t.Errorf("Limit = %d, want 10", f.Limit)
+	}
+	wantFrom := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	if f.From == nil || !f.From.Equal(wantFrom) {
+		t.Errorf("From = %v, want %v", f.From, wantFrom)
+	}
+	wantTo := time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC)
+	if f.To == nil || !f.To.Equal(wantTo) {
+		t.Errorf("To = %v, want %v", f.To, wantTo)
+	}
+	if f.MinPoints == nil || *f.MinPoints != 50 {
+		t.Errorf("MinPoints = %v, want 50", f.MinPoints)
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		values url.Values
+	}{
+		{"bad from date", url.Values{"from": {"01/01/2022"}}},
+		{"bad to date", url.Values{"to": {"not-a-date"}}},
+		{"non-numeric minPoints", url.Values{"minPoints": {"fifty"}}},
+		{"non-numeric limit", url.Values{"limit": {"ten"}}},
+		{"zero limit", url.Values{"limit": {"0"}}},
+		{"negative limit", url.Values{"limit": {"-5"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseFilter(tt.values); err == nil {
+				t.Fatalf("ParseFilter(%v) error = nil, want error", tt.values)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package query
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// EnsureIndexes creates the indexes backing filtered listing and the
+// stats aggregation (retailer/purchaseDate/points lookups and ranges).
+func EnsureIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "retailer", Value: 1}}},
+		{Keys: bson.D{{Key: "purchaseDate", Value: 1}}},
+		{Keys: bson.D{{Key: "points", Value: 1}}},
+	})
+	return err
+}
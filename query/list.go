@@ -0,0 +1,68 @@
+package query
+
+import (
+	"context"
+
+	"github.com/viswasandrews/fetch-receipt-solution/receipt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Page is one cursor-paginated page of receipts.
+type Page struct {
+	Receipts   []receipt.Receipt `json:"receipts"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+}
+
+// List runs f against col and returns one page of matching receipts,
+// ordered by _id so the cursor stays stable across pages.
+func List(ctx context.Context, col *mongo.Collection, f Filter) (Page, error) {
+	mongoFilter := bson.M{}
+
+	if f.Retailer != "" {
+		mongoFilter["retailer"] = f.Retailer
+	}
+
+	if f.From != nil || f.To != nil {
+		dateFilter := bson.M{}
+		if f.From != nil {
+			dateFilter["$gte"] = f.From.Format("2006-01-02")
+		}
+		if f.To != nil {
+			dateFilter["$lte"] = f.To.Format("2006-01-02")
+		}
+		mongoFilter["purchaseDate"] = dateFilter
+	}
+
+	if f.MinPoints != nil {
+		mongoFilter["points"] = bson.M{"$gte": *f.MinPoints}
+	}
+
+	if f.Cursor != "" {
+		mongoFilter["_id"] = bson.M{"$gt": f.Cursor}
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	// Fetch one extra document so we can tell whether another page follows.
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: mongoFilter}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit + 1}},
+	}
+
+	var receipts []receipt.Receipt
+	if err := runAggregate(ctx, col, pipeline, &receipts); err != nil {
+		return Page{}, err
+	}
+
+	page := Page{Receipts: receipts}
+	if int64(len(receipts)) > limit {
+		page.Receipts = receipts[:limit]
+		page.NextCursor = page.Receipts[limit-1].ID
+	}
+	return page, nil
+}
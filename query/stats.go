@@ -0,0 +1,119 @@
+package query
+
+import (
+	"context"
+
+	"github.com/viswasandrews/fetch-receipt-solution/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// topItemsLimit bounds the top-items-by-frequency rollup.
+const topItemsLimit = 10
+
+// RetailerCount is the number of receipts submitted for one retailer.
+type RetailerCount struct {
+	Retailer string `bson:"_id" json:"retailer"`
+	Count    int64  `bson:"count" json:"count"`
+}
+
+// DailyAveragePoints is the average points awarded on one purchase date.
+type DailyAveragePoints struct {
+	Date          string  `bson:"_id" json:"date"`
+	AveragePoints float64 `bson:"averagePoints" json:"averagePoints"`
+}
+
+// ItemFrequency is how often an item description appears across receipts.
+type ItemFrequency struct {
+	Description string `bson:"_id" json:"description"`
+	Count       int64  `bson:"count" json:"count"`
+}
+
+// Stats is the rollup returned by GET /api/receipts/stats.
+type Stats struct {
+	ReceiptsByRetailer []RetailerCount      `json:"receiptsByRetailer"`
+	AveragePointsByDay []DailyAveragePoints `json:"averagePointsByDay"`
+	TopItems           []ItemFrequency      `json:"topItems"`
+}
+
+// Aggregate runs the rollup pipelines backing GET /api/receipts/stats.
+func Aggregate(ctx context.Context, col *mongo.Collection) (Stats, error) {
+	byRetailer, err := receiptsByRetailer(ctx, col)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	byDay, err := averagePointsByDay(ctx, col)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	topItems, err := topItemsByFrequency(ctx, col)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	return Stats{
+		ReceiptsByRetailer: byRetailer,
+		AveragePointsByDay: byDay,
+		TopItems:           topItems,
+	}, nil
+}
+
+func receiptsByRetailer(ctx context.Context, col *mongo.Collection) ([]RetailerCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$retailer"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}}}},
+	}
+	var out []RetailerCount
+	if err := runAggregate(ctx, col, pipeline, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func averagePointsByDay(ctx context.Context, col *mongo.Collection) ([]DailyAveragePoints, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$purchaseDate"},
+			{Key: "averagePoints", Value: bson.D{{Key: "$avg", Value: "$points"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+	var out []DailyAveragePoints
+	if err := runAggregate(ctx, col, pipeline, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func topItemsByFrequency(ctx context.Context, col *mongo.Collection) ([]ItemFrequency, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$items"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$items.shortDescription"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}}}},
+		{{Key: "$limit", Value: topItemsLimit}},
+	}
+	var out []ItemFrequency
+	if err := runAggregate(ctx, col, pipeline, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func runAggregate(ctx context.Context, col *mongo.Collection, pipeline mongo.Pipeline, out interface{}) error {
+	return metrics.ObserveMongoOp("aggregate", func() error {
+		cursor, err := col.Aggregate(ctx, pipeline)
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(ctx)
+		return cursor.All(ctx, out)
+	})
+}
@@ -0,0 +1,41 @@
+// Package receipt holds the Receipt domain type shared by the HTTP
+// handlers, the rules engine, and anything else that needs to read a
+// submitted receipt without importing the rest of the server.
+package receipt
+
+// Receipt is a single submitted receipt, as stored in Mongo.
+type Receipt struct {
+	ID           string `json:"id" bson:"_id"`
+	Retailer     string `json:"retailer"`
+	PurchaseDate string `json:"purchaseDate"`
+	PurchaseTime string `json:"purchaseTime"`
+	Items        []Item `json:"items"`
+	Total        string `json:"total"`
+	// Points is computed and persisted at insert time so list/stats
+	// aggregations don't have to re-run the rules engine per document.
+	Points int `json:"points" bson:"points"`
+	// Explanation is the per-rule breakdown computed alongside Points at
+	// insert time and persisted so GET .../points?explain=1 reflects the
+	// rules that actually produced Points, rather than whatever the rules
+	// config happens to say if it's since been retuned.
+	Explanation Explanation `json:"-" bson:"explanation"`
+}
+
+// Item is a single line item on a Receipt.
+type Item struct {
+	ShortDescription string `json:"shortDescription"`
+	Price            string `json:"price"`
+}
+
+// RuleResult is one rule's contribution to a receipt's total score.
+type RuleResult struct {
+	Rule   string `json:"rule" bson:"rule"`
+	Points int    `json:"points" bson:"points"`
+	Reason string `json:"reason" bson:"reason"`
+}
+
+// Explanation is the per-rule breakdown behind a receipt's total score.
+type Explanation struct {
+	Total   int          `json:"total" bson:"total"`
+	Results []RuleResult `json:"results" bson:"results"`
+}
@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/viswasandrews/fetch-receipt-solution/receipt"
+)
+
+// AlphanumericRetailer awards one point per alphanumeric character in the
+// retailer name.
+type AlphanumericRetailer struct{}
+
+func (AlphanumericRetailer) Name() string { return "alphanumeric_retailer" }
+
+func (AlphanumericRetailer) Points(r *receipt.Receipt) int {
+	count := 0
+	for _, char := range r.Retailer {
+		if unicode.IsLetter(char) || unicode.IsDigit(char) {
+			count++
+		}
+	}
+	return count
+}
+
+func (AlphanumericRetailer) Describe() string {
+	return "one point for every alphanumeric character in the retailer name"
+}
+
+// RoundDollar awards PointsAwarded if the total has no cents.
+type RoundDollar struct {
+	PointsAwarded int
+}
+
+func (RoundDollar) Name() string { return "round_dollar" }
+
+func (rd RoundDollar) Points(r *receipt.Receipt) int {
+	total, _ := strconv.ParseFloat(r.Total, 64)
+	if total == float64(int(total)) {
+		return rd.PointsAwarded
+	}
+	return 0
+}
+
+func (rd RoundDollar) Describe() string {
+	return fmt.Sprintf("%d points if the total is a round dollar amount with no cents", rd.PointsAwarded)
+}
+
+// QuarterMultiple awards PointsAwarded if the total is a multiple of 0.25.
+type QuarterMultiple struct {
+	PointsAwarded int
+}
+
+func (QuarterMultiple) Name() string { return "quarter_multiple" }
+
+func (qm QuarterMultiple) Points(r *receipt.Receipt) int {
+	total, _ := strconv.ParseFloat(r.Total, 64)
+	if total/0.25 == float64(int(total/0.25)) {
+		return qm.PointsAwarded
+	}
+	return 0
+}
+
+func (qm QuarterMultiple) Describe() string {
+	return fmt.Sprintf("%d points if the total is a multiple of 0.25", qm.PointsAwarded)
+}
+
+// ItemPairs awards PointsPerPair for every two items on the receipt.
+type ItemPairs struct {
+	PointsPerPair int
+}
+
+func (ItemPairs) Name() string { return "item_pairs" }
+
+func (ip ItemPairs) Points(r *receipt.Receipt) int {
+	return (len(r.Items) / 2) * ip.PointsPerPair
+}
+
+func (ip ItemPairs) Describe() string {
+	return fmt.Sprintf("%d points for every two items on the receipt", ip.PointsPerPair)
+}
+
+// ItemDescriptionMultiple awards Multiplier * price, rounded up, for every
+// item whose trimmed description length is a multiple of three.
+type ItemDescriptionMultiple struct {
+	Multiplier float64
+}
+
+func (ItemDescriptionMultiple) Name() string { return "item_description_multiple_of_three" }
+
+func (im ItemDescriptionMultiple) Points(r *receipt.Receipt) int {
+	points := 0
+	for _, item := range r.Items {
+		if len(strings.TrimSpace(item.ShortDescription))%3 != 0 {
+			continue
+		}
+		price, _ := strconv.ParseFloat(item.Price, 64)
+		points += int(math.Ceil(price * im.Multiplier))
+	}
+	return points
+}
+
+func (im ItemDescriptionMultiple) Describe() string {
+	return fmt.Sprintf("if the trimmed item description length is a multiple of 3, award ceil(price * %g) points", im.Multiplier)
+}
+
+// OddDay awards PointsAwarded if the day in the purchase date is odd.
+type OddDay struct {
+	PointsAwarded int
+}
+
+func (OddDay) Name() string { return "odd_day" }
+
+func (od OddDay) Points(r *receipt.Receipt) int {
+	purchaseDate, err := time.Parse("2006-01-02", r.PurchaseDate)
+	if err != nil {
+		return 0
+	}
+	if purchaseDate.Day()%2 != 0 {
+		return od.PointsAwarded
+	}
+	return 0
+}
+
+func (od OddDay) Describe() string {
+	return fmt.Sprintf("%d points if the day in the purchase date is odd", od.PointsAwarded)
+}
+
+// AfternoonWindow awards PointsAwarded if the purchase time falls strictly
+// between Start and End.
+type AfternoonWindow struct {
+	PointsAwarded int
+	Start         time.Time
+	End           time.Time
+}
+
+// NewAfternoonWindow parses start/end as "15:04" and returns a configured
+// AfternoonWindow rule.
+func NewAfternoonWindow(points int, start, end string) (AfternoonWindow, error) {
+	startTime, err := time.Parse("15:04", start)
+	if err != nil {
+		return AfternoonWindow{}, fmt.Errorf("rules: invalid afternoon_window start %q: %w", start, err)
+	}
+	endTime, err := time.Parse("15:04", end)
+	if err != nil {
+		return AfternoonWindow{}, fmt.Errorf("rules: invalid afternoon_window end %q: %w", end, err)
+	}
+	return AfternoonWindow{PointsAwarded: points, Start: startTime, End: endTime}, nil
+}
+
+func (AfternoonWindow) Name() string { return "afternoon_window" }
+
+func (aw AfternoonWindow) Points(r *receipt.Receipt) int {
+	purchaseTime, err := time.Parse("15:04", r.PurchaseTime)
+	if err != nil {
+		return 0
+	}
+	if purchaseTime.After(aw.Start) && purchaseTime.Before(aw.End) {
+		return aw.PointsAwarded
+	}
+	return 0
+}
+
+func (aw AfternoonWindow) Describe() string {
+	return fmt.Sprintf("%d points if the time of purchase is after %s and before %s", aw.PointsAwarded, aw.Start.Format("15:04"), aw.End.Format("15:04"))
+}
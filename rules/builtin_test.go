@@ -0,0 +1,145 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/viswasandrews/fetch-receipt-solution/receipt"
+)
+
+func TestAlphanumericRetailer(t *testing.T) {
+	tests := []struct {
+		retailer string
+		want     int
+	}{
+		{"Target", 6},
+		{"M&M Corner Market", 14},
+		{"   ", 0},
+	}
+	for _, tt := range tests {
+		r := &receipt.Receipt{Retailer: tt.retailer}
+		if got := (AlphanumericRetailer{}).Points(r); got != tt.want {
+			t.Errorf("AlphanumericRetailer.Points(%q) = %d, want %d", tt.retailer, got, tt.want)
+		}
+	}
+}
+
+func TestRoundDollar(t *testing.T) {
+	rule := RoundDollar{PointsAwarded: 50}
+	tests := []struct {
+		total string
+		want  int
+	}{
+		{"35.00", 50},
+		{"35.35", 0},
+	}
+	for _, tt := range tests {
+		r := &receipt.Receipt{Total: tt.total}
+		if got := rule.Points(r); got != tt.want {
+			t.Errorf("RoundDollar.Points(%q) = %d, want %d", tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestQuarterMultiple(t *testing.T) {
+	rule := QuarterMultiple{PointsAwarded: 25}
+	tests := []struct {
+		total string
+		want  int
+	}{
+		{"35.50", 25},
+		{"35.25", 25},
+		{"35.10", 0},
+	}
+	for _, tt := range tests {
+		r := &receipt.Receipt{Total: tt.total}
+		if got := rule.Points(r); got != tt.want {
+			t.Errorf("QuarterMultiple.Points(%q) = %d, want %d", tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestItemPairs(t *testing.T) {
+	rule := ItemPairs{PointsPerPair: 5}
+	tests := []struct {
+		count int
+		want  int
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 5},
+		{5, 10},
+	}
+	for _, tt := range tests {
+		r := &receipt.Receipt{Items: make([]receipt.Item, tt.count)}
+		if got := rule.Points(r); got != tt.want {
+			t.Errorf("ItemPairs.Points(%d items) = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestItemDescriptionMultiple(t *testing.T) {
+	rule := ItemDescriptionMultiple{Multiplier: 0.2}
+	r := &receipt.Receipt{
+		Items: []receipt.Item{
+			{ShortDescription: "Emils Cheese Pizza", Price: "12.25"}, // trimmed len 18, multiple of 3
+			{ShortDescription: "Gum", Price: "1.00"},                 // trimmed len 3, multiple of 3
+			{ShortDescription: "Klarbrunn 12-PK 12 FL OZ", Price: "12.00"},
+		},
+	}
+	// 18 % 3 == 0 -> ceil(12.25*0.2) = ceil(2.45) = 3
+	// 3 % 3 == 0 -> ceil(1.00*0.2) = ceil(0.2) = 1
+	// len("Klarbrunn 12-PK 12 FL OZ") = 24, 24 % 3 == 0 -> ceil(12.00*0.2) = ceil(2.4) = 3
+	if got, want := rule.Points(r), 7; got != want {
+		t.Errorf("ItemDescriptionMultiple.Points() = %d, want %d", got, want)
+	}
+}
+
+func TestOddDay(t *testing.T) {
+	rule := OddDay{PointsAwarded: 6}
+	tests := []struct {
+		date string
+		want int
+	}{
+		{"2022-01-01", 6},
+		{"2022-01-02", 0},
+		{"not-a-date", 0},
+	}
+	for _, tt := range tests {
+		r := &receipt.Receipt{PurchaseDate: tt.date}
+		if got := rule.Points(r); got != tt.want {
+			t.Errorf("OddDay.Points(%q) = %d, want %d", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestAfternoonWindow(t *testing.T) {
+	rule, err := NewAfternoonWindow(10, "14:00", "16:00")
+	if err != nil {
+		t.Fatalf("NewAfternoonWindow() error = %v", err)
+	}
+
+	tests := []struct {
+		time string
+		want int
+	}{
+		{"14:33", 10},
+		{"13:01", 0},
+		{"16:00", 0}, // End is exclusive
+		{"14:00", 0}, // Start is exclusive
+	}
+	for _, tt := range tests {
+		r := &receipt.Receipt{PurchaseTime: tt.time}
+		if got := rule.Points(r); got != tt.want {
+			t.Errorf("AfternoonWindow.Points(%q) = %d, want %d", tt.time, got, tt.want)
+		}
+	}
+}
+
+func TestNewAfternoonWindowInvalidTime(t *testing.T) {
+	if _, err := NewAfternoonWindow(10, "not-a-time", "16:00"); err == nil {
+		t.Fatal("NewAfternoonWindow() with invalid start, want error")
+	}
+	if _, err := NewAfternoonWindow(10, "14:00", "not-a-time"); err == nil {
+		t.Fatal("NewAfternoonWindow() with invalid end, want error")
+	}
+}
@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ruleConfig is one entry in the rules config file. Not every field
+// applies to every rule; unused fields are simply ignored.
+type ruleConfig struct {
+	Name       string  `json:"name" yaml:"name"`
+	Enabled    bool    `json:"enabled" yaml:"enabled"`
+	Points     int     `json:"points" yaml:"points"`
+	Multiplier float64 `json:"multiplier" yaml:"multiplier"`
+	Start      string  `json:"start" yaml:"start"`
+	End        string  `json:"end" yaml:"end"`
+}
+
+type fileConfig struct {
+	Rules []ruleConfig `json:"rules" yaml:"rules"`
+}
+
+// LoadEngine reads a rules config file (YAML, or JSON if path ends in
+// .json) and builds an Engine from the enabled entries, tuned with the
+// constants given in the file.
+func LoadEngine(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg fileConfig
+	if strings.HasSuffix(path, ".json") {
+		err = json.Unmarshal(data, &cfg)
+	} else {
+		err = yaml.Unmarshal(data, &cfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rules: parsing %s: %w", path, err)
+	}
+
+	var active []Rule
+	for _, rc := range cfg.Rules {
+		if !rc.Enabled {
+			continue
+		}
+		rule, err := build(rc)
+		if err != nil {
+			return nil, err
+		}
+		active = append(active, rule)
+	}
+	return &Engine{rules: active}, nil
+}
+
+// build constructs the Rule named by rc, tuned with rc's params.
+func build(rc ruleConfig) (Rule, error) {
+	switch rc.Name {
+	case "alphanumeric_retailer":
+		return AlphanumericRetailer{}, nil
+	case "round_dollar":
+		return RoundDollar{PointsAwarded: rc.Points}, nil
+	case "quarter_multiple":
+		return QuarterMultiple{PointsAwarded: rc.Points}, nil
+	case "item_pairs":
+		return ItemPairs{PointsPerPair: rc.Points}, nil
+	case "item_description_multiple_of_three":
+		return ItemDescriptionMultiple{Multiplier: rc.Multiplier}, nil
+	case "odd_day":
+		return OddDay{PointsAwarded: rc.Points}, nil
+	case "afternoon_window":
+		return NewAfternoonWindow(rc.Points, rc.Start, rc.End)
+	default:
+		return nil, fmt.Errorf("rules: unknown rule %q", rc.Name)
+	}
+}
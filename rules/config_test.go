@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/viswasandrews/fetch-receipt-solution/receipt"
+)
+
+const testYAML = `
+rules:
+  - name: alphanumeric_retailer
+    enabled: true
+  - name: round_dollar
+    enabled: true
+    points: 50
+  - name: odd_day
+    enabled: false
+    points: 6
+`
+
+func TestLoadEngineYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	writeFile(t, path, testYAML)
+
+	engine, err := LoadEngine(path)
+	if err != nil {
+		t.Fatalf("LoadEngine() error = %v", err)
+	}
+
+	// alphanumeric_retailer (6) + round_dollar (50); odd_day disabled.
+	r := &receipt.Receipt{Retailer: "Target", Total: "10.00", PurchaseDate: "2022-01-01"}
+	if got, want := engine.Points(r), 56; got != want {
+		t.Errorf("engine.Points() = %d, want %d", got, want)
+	}
+}
+
+func TestLoadEngineJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeFile(t, path, `{"rules":[{"name":"item_pairs","enabled":true,"points":5}]}`)
+
+	engine, err := LoadEngine(path)
+	if err != nil {
+		t.Fatalf("LoadEngine() error = %v", err)
+	}
+
+	r := &receipt.Receipt{Items: make([]receipt.Item, 4)}
+	if got, want := engine.Points(r), 10; got != want {
+		t.Errorf("engine.Points() = %d, want %d", got, want)
+	}
+}
+
+func TestLoadEngineMissingFile(t *testing.T) {
+	if _, err := LoadEngine(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("LoadEngine() with missing file, want error")
+	}
+}
+
+func TestLoadEngineUnknownRule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	writeFile(t, path, "rules:\n  - name: not_a_real_rule\n    enabled: true\n")
+
+	if _, err := LoadEngine(path); err == nil {
+		t.Fatal("LoadEngine() with unknown rule name, want error")
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write test fixture: %v", err)
+	}
+}
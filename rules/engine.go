@@ -0,0 +1,37 @@
+package rules
+
+import "github.com/viswasandrews/fetch-receipt-solution/receipt"
+
+// Engine scores a receipt against an ordered set of active rules.
+type Engine struct {
+	rules []Rule
+}
+
+// Points returns the total points awarded across all active rules.
+func (e *Engine) Points(r *receipt.Receipt) int {
+	total := 0
+	for _, rule := range e.rules {
+		total += rule.Points(r)
+	}
+	return total
+}
+
+// Explain returns the total plus a per-rule breakdown, for debugging
+// disputes over why a receipt scored the way it did. The result is
+// persisted on receipt.Receipt.Explanation at insert time so a later
+// ?explain=1 read reflects the rules that actually scored the receipt,
+// even if the rules config is retuned afterward.
+func (e *Engine) Explain(r *receipt.Receipt) receipt.Explanation {
+	results := make([]receipt.RuleResult, 0, len(e.rules))
+	total := 0
+	for _, rule := range e.rules {
+		points := rule.Points(r)
+		total += points
+		results = append(results, receipt.RuleResult{
+			Rule:   rule.Name(),
+			Points: points,
+			Reason: rule.Describe(),
+		})
+	}
+	return receipt.Explanation{Total: total, Results: results}
+}
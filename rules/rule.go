@@ -0,0 +1,16 @@
+// Package rules implements the points scoring engine as a set of
+// pluggable rules loaded from a config file, so operators can enable,
+// disable, or tune them without recompiling.
+package rules
+
+import "github.com/viswasandrews/fetch-receipt-solution/receipt"
+
+// Rule is a single scoring rule.
+type Rule interface {
+	// Name is the stable identifier used in config files and explain output.
+	Name() string
+	// Points returns the points this rule awards for the given receipt.
+	Points(r *receipt.Receipt) int
+	// Describe explains, in human terms, why this rule awards points.
+	Describe() string
+}
@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Problem is an RFC 7807 problem+json document listing every field error
+// found on a receipt, so a client can fix all of them in one round trip.
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Errors []FieldError `json:"errors"`
+}
+
+// WriteProblem writes errs to w as an RFC 7807 problem+json response with
+// a 400 status.
+func WriteProblem(w http.ResponseWriter, errs []FieldError) {
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  "the receipt failed schema validation",
+		Status: http.StatusBadRequest,
+		Errors: errs,
+	}
+	body, _ := json.Marshal(problem)
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(http.StatusBadRequest)
+	w.Write(body)
+}
@@ -0,0 +1,59 @@
+// Package validation checks submitted receipts against the Fetch receipt
+// schema before they reach Mongo, collecting every field error instead of
+// failing on the first one.
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/viswasandrews/fetch-receipt-solution/receipt"
+)
+
+var (
+	retailerPattern = regexp.MustCompile(`^[\w\s\-&]+$`)
+	amountPattern   = regexp.MustCompile(`^\d+\.\d{2}$`)
+)
+
+// FieldError is a single field-level validation failure.
+type FieldError struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+// Validate checks r against the Fetch receipt schema and returns every
+// field error found. A nil/empty result means r is valid.
+func Validate(r *receipt.Receipt) []FieldError {
+	var errs []FieldError
+
+	if !retailerPattern.MatchString(r.Retailer) {
+		errs = append(errs, FieldError{Field: "retailer", Detail: `must match ^[\w\s\-&]+$`})
+	}
+
+	if _, err := time.Parse("2006-01-02", r.PurchaseDate); err != nil {
+		errs = append(errs, FieldError{Field: "purchaseDate", Detail: "must be a valid date in YYYY-MM-DD format"})
+	}
+
+	if _, err := time.Parse("15:04", r.PurchaseTime); err != nil {
+		errs = append(errs, FieldError{Field: "purchaseTime", Detail: "must be a valid time in HH:MM format"})
+	}
+
+	if !amountPattern.MatchString(r.Total) {
+		errs = append(errs, FieldError{Field: "total", Detail: `must match ^\d+\.\d{2}$`})
+	}
+
+	if len(r.Items) < 1 {
+		errs = append(errs, FieldError{Field: "items", Detail: "must contain at least one item"})
+	}
+	for i, item := range r.Items {
+		if !amountPattern.MatchString(item.Price) {
+			errs = append(errs, FieldError{
+				Field:  fmt.Sprintf("items[%d].price", i),
+				Detail: `must match ^\d+\.\d{2}$`,
+			})
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/viswasandrews/fetch-receipt-solution/receipt"
+)
+
+func validReceipt() receipt.Receipt {
+	return receipt.Receipt{
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Total:        "35.35",
+		Items: []receipt.Item{
+			{ShortDescription: "Mountain Dew 12PK", Price: "6.49"},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(r *receipt.Receipt)
+		wantErr string
+	}{
+		{
+			name:   "valid receipt",
+			modify: func(r *receipt.Receipt) {},
+		},
+		{
+			name:    "retailer with disallowed characters",
+			modify:  func(r *receipt.Receipt) { r.Retailer = "Target!!" },
+			wantErr: "retailer",
+		},
+		{
+			name:    "retailer allows spaces, dashes, and ampersands",
+			modify:  func(r *receipt.Receipt) { r.Retailer = "M&M Corner-Store" },
+			wantErr: "",
+		},
+		{
+			name:    "malformed purchase date",
+			modify:  func(r *receipt.Receipt) { r.PurchaseDate = "01/01/2022" },
+			wantErr: "purchaseDate",
+		},
+		{
+			name:    "malformed purchase time",
+			modify:  func(r *receipt.Receipt) { r.PurchaseTime = "1:01 PM" },
+			wantErr: "purchaseTime",
+		},
+		{
+			name:    "total missing cents",
+			modify:  func(r *receipt.Receipt) { r.Total = "35" },
+			wantErr: "total",
+		},
+		{
+			name:    "no items",
+			modify:  func(r *receipt.Receipt) { r.Items = nil },
+			wantErr: "items",
+		},
+		{
+			name: "item with malformed price",
+			modify: func(r *receipt.Receipt) {
+				r.Items = append(r.Items, receipt.Item{ShortDescription: "Gum", Price: "free"})
+			},
+			wantErr: "items[1].price",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := validReceipt()
+			tt.modify(&r)
+
+			errs := Validate(&r)
+
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Fatalf("Validate() = %v, want no errors", errs)
+				}
+				return
+			}
+
+			found := false
+			for _, e := range errs {
+				if e.Field == tt.wantErr {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf("Validate() = %v, want an error on field %q", errs, tt.wantErr)
+			}
+		})
+	}
+}